@@ -0,0 +1,264 @@
+package atom
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// atomNS and atom03NS are the root namespaces that distinguish an Atom 1.0
+// document from its Atom 0.3 predecessor.
+const (
+	atomNS   = "http://www.w3.org/2005/Atom"
+	atom03NS = "http://purl.org/atom/ns#"
+)
+
+// Atom03Feed is the top level element of an Atom 0.3 feed
+// (http://purl.org/atom/ns#), the format RFC 4287 superseded. It exists only
+// as an intermediate decoding target: Parse normalizes it into a Feed so
+// that the rest of this package never has to special-case the old format.
+type Atom03Feed struct {
+	XMLName xml.Name `xml:"http://purl.org/atom/ns# feed"`
+	Common
+
+	Version string `xml:"version,attr"`
+
+	Author      *Person        `xml:"author"`
+	Contributor []Person       `xml:"contributor,omitempty"`
+	Copyright   string         `xml:"copyright,omitempty"`
+	Generator   *Generator     `xml:"generator,omitempty"`
+	ID          string         `xml:"id"`
+	Link        []Link         `xml:"link,omitempty"`
+	Modified    TimeStr        `xml:"modified"`
+	Tagline     *Atom03Content `xml:"tagline,omitempty"`
+	Title       *Atom03Content `xml:"title"`
+
+	Entry []Atom03Entry `xml:"entry"`
+}
+
+// Atom03Entry is a single entry inside an Atom03Feed.
+type Atom03Entry struct {
+	XMLName xml.Name `xml:"http://purl.org/atom/ns# entry"`
+	Common
+
+	Author   *Person        `xml:"author"`
+	Content  *Atom03Content `xml:"content,omitempty"`
+	Created  TimeStr        `xml:"created,omitempty"`
+	ID       string         `xml:"id"`
+	Issued   TimeStr        `xml:"issued,omitempty"`
+	Link     []Link         `xml:"link,omitempty"`
+	Modified TimeStr        `xml:"modified"`
+	Summary  *Atom03Content `xml:"summary,omitempty"`
+	Title    *Atom03Content `xml:"title"`
+}
+
+// Atom03Content is an Atom 0.3 text construct, e.g. <title>, <tagline>,
+// <summary> or <content>. Unlike Atom 1.0's Text, the body may be
+// base64-encoded (mode="base64") and Type uses the short "text"/"html"/
+// "xhtml" forms rather than a MIME type.
+type Atom03Content struct {
+	Common
+
+	Type string `xml:"type,attr,omitempty"`
+	Mode string `xml:"mode,attr,omitempty"`
+	Body string `xml:",innerxml"`
+}
+
+// Parse decodes an Atom feed of either version: Atom 1.0
+// (http://www.w3.org/2005/Atom) or Atom 0.3 (http://purl.org/atom/ns#). It
+// sniffs the root element's namespace and always returns a normalized Feed
+// in the Atom 1.0 shape, so Validate and marshalling work the same way
+// regardless of which version was on the wire. Feed.SourceVersion records
+// which version was actually parsed.
+func Parse(r io.Reader) (*Feed, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rootNS, err := sniffRootNamespace(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch rootNS {
+	case atomNS:
+		var feed Feed
+		if err := decodeAtomXML(data, &feed); err != nil {
+			return nil, err
+		}
+		feed.SourceVersion = "1.0"
+		return &feed, nil
+
+	case atom03NS:
+		var old Atom03Feed
+		if err := decodeAtomXML(data, &old); err != nil {
+			return nil, err
+		}
+		return atom03ToFeed(&old), nil
+
+	default:
+		return nil, fmt.Errorf("atom: unrecognized feed namespace: %q", rootNS)
+	}
+}
+
+// sniffRootNamespace returns the namespace of the document's root element.
+func sniffRootNamespace(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charset.NewReaderLabel
+
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name.Space, nil
+		}
+	}
+}
+
+// decodeAtomXML decodes data into v, with charset detection enabled the same
+// way testLoadAtomFile does for an ordinary xml.Decoder.
+func decodeAtomXML(data []byte, v interface{}) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charset.NewReaderLabel
+
+	return decoder.Decode(v)
+}
+
+// normalizeAtom03Type maps an Atom 0.3 short type ("text", "html", "xhtml")
+// to its Atom 1.0 MIME equivalent. Values that already contain a "/" (i.e.
+// are already a MIME type) are returned unchanged.
+func normalizeAtom03Type(t string) string {
+	if t == "" || strings.Contains(t, "/") {
+		return t
+	}
+
+	switch t {
+	case "text":
+		return "text/plain"
+	case "html":
+		return "text/html"
+	case "xhtml":
+		return "application/xhtml+xml"
+	default:
+		return t
+	}
+}
+
+// atom03Body decodes an Atom 0.3 content body, base64-decoding it first if
+// mode="base64" was set.
+func atom03Body(c *Atom03Content) string {
+	if c == nil {
+		return ""
+	}
+
+	if !strings.EqualFold(c.Mode, "base64") {
+		return c.Body
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(c.Body))
+	if err != nil {
+		return c.Body
+	}
+
+	return string(decoded)
+}
+
+// atom03Text converts an Atom 0.3 content construct into its Atom 1.0 Text
+// equivalent. isContent mirrors Text.validate's isContent: Entry.Content may
+// carry an arbitrary MIME type, so its Type is normalized to a full MIME
+// type, but Summary must keep the short "text"/"html"/"xhtml" form.
+func atom03Text(c *Atom03Content, isContent bool) *Text {
+	if c == nil {
+		return nil
+	}
+
+	t := c.Type
+	if isContent {
+		t = normalizeAtom03Type(t)
+	}
+
+	return &Text{
+		Common: c.Common,
+		Type:   t,
+		Body:   atom03Body(c),
+	}
+}
+
+// atom03ToFeed normalizes an Atom 0.3 feed into the Atom 1.0 Feed shape.
+func atom03ToFeed(src *Atom03Feed) *Feed {
+	feed := &Feed{
+		XMLName:       xml.Name{Space: atomNS, Local: "feed"},
+		Common:        src.Common,
+		Contributor:   src.Contributor,
+		Generator:     src.Generator,
+		ID:            src.ID,
+		Link:          src.Link,
+		Rights:        src.Copyright,
+		Title:         atom03Body(src.Title),
+		Updated:       src.Modified,
+		SourceVersion: "0.3",
+	}
+
+	if t, err := TimeParse(feed.Updated); err == nil {
+		feed.UpdatedAt = t
+	}
+
+	if src.Author != nil {
+		feed.Author = []Person{*src.Author}
+	}
+
+	if src.Tagline != nil {
+		feed.Subtitle = atom03Body(src.Tagline)
+	}
+
+	for _, entry := range src.Entry {
+		feed.Entry = append(feed.Entry, atom03ToEntry(entry))
+	}
+
+	return feed
+}
+
+// atom03ToEntry normalizes an Atom 0.3 entry into the Atom 1.0 Entry shape.
+func atom03ToEntry(src Atom03Entry) Entry {
+	entry := Entry{
+		XMLName:   xml.Name{Space: atomNS, Local: "entry"},
+		Common:    src.Common,
+		Content:   atom03Text(src.Content, true),
+		ID:        src.ID,
+		Link:      src.Link,
+		Published: src.Issued,
+		Summary:   atom03Text(src.Summary, false),
+		Title:     atom03Body(src.Title),
+		Updated:   src.Modified,
+	}
+
+	if entry.Published == "" {
+		entry.Published = src.Created
+	}
+
+	if t, err := TimeParse(entry.Updated); err == nil {
+		entry.UpdatedAt = t
+	}
+
+	if entry.Published != "" {
+		if t, err := TimeParse(entry.Published); err == nil {
+			entry.PublishedAt = t
+		}
+	}
+
+	if src.Author != nil {
+		entry.Author = []Person{*src.Author}
+	}
+
+	return entry
+}
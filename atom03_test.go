@@ -0,0 +1,65 @@
+package atom
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAtom03(t *testing.T) {
+	feed, err := Parse(strings.NewReader(`<?xml version="1.0" encoding="utf-8"?>
+<feed version="0.3" xmlns="http://purl.org/atom/ns#">
+	<title>Test feed</title>
+	<tagline>Test subtitle</tagline>
+	<link rel="alternate" type="text/html" href="http://www.test.com/blog"/>
+	<modified>2006-11-04T09:11:03-08:00</modified>
+	<author><name>John Doe</name></author>
+	<id>http://www.test.com/blog</id>
+	<copyright>Test Corp TM</copyright>
+	<entry>
+		<title>Entry 1</title>
+		<link rel="alternate" type="text/html" href="http://www.test.com/blog/1"/>
+		<id>http://www.test.com/blog/1</id>
+		<issued>2006-11-03T09:11:03-08:00</issued>
+		<modified>2006-11-04T09:11:03-08:00</modified>
+		<summary type="text">Entry 1 summary</summary>
+		<content type="text" mode="base64">SGVsbG8sIHdvcmxkIQ==</content>
+	</entry>
+</feed>`))
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if feed.SourceVersion != "0.3" {
+		t.Fatal("SourceVersion was not set to 0.3")
+	}
+
+	if feed.Title != "Test feed" || feed.Subtitle != "Test subtitle" || feed.Rights != "Test Corp TM" {
+		t.Fatal("Feed.Title, Feed.Subtitle or Feed.Rights did not convert correctly.")
+	}
+
+	if len(feed.Author) != 1 || feed.Author[0].Name != "John Doe" {
+		t.Fatal("Feed.Author did not convert correctly.")
+	}
+
+	if len(feed.Entry) != 1 {
+		t.Fatal("Feed.Entry did not convert correctly.")
+	}
+
+	entry := feed.Entry[0]
+	if entry.Published != "2006-11-03T09:11:03-08:00" || entry.Updated != "2006-11-04T09:11:03-08:00" {
+		t.Fatal("Entry.Published or Entry.Updated did not convert correctly.")
+	}
+
+	if entry.Content == nil || entry.Content.Type != "text/plain" || entry.Content.Body != "Hello, world!" {
+		t.Fatal("Entry.Content did not decode and normalize correctly.")
+	}
+
+	if entry.Summary == nil || entry.Summary.Type != "text" || entry.Summary.Body != "Entry 1 summary" {
+		t.Fatal("Entry.Summary should keep the short type form, not Content's MIME normalization.")
+	}
+
+	if err := feed.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
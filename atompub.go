@@ -0,0 +1,142 @@
+package atom
+
+import (
+	"encoding/xml"
+	"net/url"
+)
+
+// appNS is the Atom Publishing Protocol (RFC 5023) namespace used by the
+// app:* elements in a Service or CategoryDocument.
+const appNS = "http://www.w3.org/2007/app"
+
+// Service is an Atom Publishing Protocol (RFC 5023) Service Document: the
+// root listing of a server's workspaces and the collections inside them.
+type Service struct {
+	XMLName   xml.Name    `xml:"http://www.w3.org/2007/app service"`
+	Workspace []Workspace `xml:"http://www.w3.org/2007/app workspace"`
+}
+
+// Validate validates a service document using DefaultOptions.
+func (service *Service) Validate() error {
+	return service.ValidateWith(DefaultOptions)
+}
+
+// ValidateWith validates a service document against the rules selected by
+// opts.
+func (service *Service) ValidateWith(opts *ValidationOptions) error {
+	var errs = new(ValidationError)
+
+	for _, workspace := range service.Workspace {
+		errs.Merge(workspace.validate(opts))
+	}
+
+	return errs.NilIfEmpty()
+}
+
+// Workspace is an app:workspace element, a named group of collections.
+type Workspace struct {
+	Title      string       `xml:"http://www.w3.org/2005/Atom title"`
+	Collection []Collection `xml:"http://www.w3.org/2007/app collection"`
+}
+
+// Validate an app:workspace element.
+func (workspace *Workspace) validate(opts *ValidationOptions) error {
+	var errs = new(ValidationError)
+
+	for _, collection := range workspace.Collection {
+		errs.Merge(collection.validate(opts))
+	}
+
+	return errs.NilIfEmpty()
+}
+
+// Collection is an app:collection element describing a single editable
+// member resource collection. Validation:
+//   - Href must be present and a valid url.URL.
+//   - Every Categories element must validate, see Categories.
+type Collection struct {
+	Href       string       `xml:"href,attr"`
+	Title      string       `xml:"http://www.w3.org/2005/Atom title"`
+	Accept     []string     `xml:"http://www.w3.org/2007/app accept,omitempty"`
+	Categories []Categories `xml:"http://www.w3.org/2007/app categories,omitempty"`
+}
+
+// Validate an app:collection element.
+func (collection *Collection) validate(opts *ValidationOptions) error {
+	var errs = new(ValidationError)
+
+	if collection.Href == "" {
+		errs.Add("Collection.Href can't be empty.")
+	} else if _, err := url.Parse(collection.Href); err != nil {
+		errs.AddErr(err)
+	}
+
+	for _, categories := range collection.Categories {
+		errs.Merge(categories.validate(opts))
+	}
+
+	return errs.NilIfEmpty()
+}
+
+// Categories is an app:categories element, either inline (listing Category
+// elements directly) or pointing at an external Category Document via Href.
+// Validation:
+//   - Href if present must be a valid url.URL.
+//   - Scheme if present must be a valid url.URL, reusing Category's own
+//     scheme validation.
+//   - Every Category must validate, see Category.
+type Categories struct {
+	Href     string     `xml:"href,attr,omitempty"`
+	Fixed    string     `xml:"fixed,attr,omitempty"`
+	Scheme   string     `xml:"scheme,attr,omitempty"`
+	Category []Category `xml:"http://www.w3.org/2005/Atom category,omitempty"`
+}
+
+// Validate an app:categories element.
+func (categories *Categories) validate(opts *ValidationOptions) error {
+	var errs = new(ValidationError)
+
+	if categories.Href != "" {
+		if _, err := url.Parse(categories.Href); err != nil {
+			errs.AddErr(err)
+		}
+	}
+
+	if categories.Scheme != "" {
+		if _, err := url.Parse(categories.Scheme); err != nil {
+			errs.AddErr(err)
+		}
+	}
+
+	for _, category := range categories.Category {
+		errs.Merge(category.validate(opts))
+
+		// RFC 5023 category documents always require a term, regardless
+		// of the profile in effect: Category.validate only enforces this
+		// under RFC4287Strict because plain Atom feeds have historically
+		// been lenient about it.
+		if category.Term == "" {
+			errs.Add("Categories.Category.Term can't be empty.")
+		}
+	}
+
+	return errs.NilIfEmpty()
+}
+
+// CategoryDocument is a standalone app:categories Category Document, the
+// resource a Categories.Href can point at.
+type CategoryDocument struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2007/app categories"`
+	Categories
+}
+
+// Validate validates a category document using DefaultOptions.
+func (doc *CategoryDocument) Validate() error {
+	return doc.ValidateWith(DefaultOptions)
+}
+
+// ValidateWith validates a category document against the rules selected by
+// opts.
+func (doc *CategoryDocument) ValidateWith(opts *ValidationOptions) error {
+	return doc.Categories.validate(opts)
+}
@@ -0,0 +1,81 @@
+package atom
+
+import "testing"
+
+func TestServiceDocumentValidation(t *testing.T) {
+	service := Service{
+		Workspace: []Workspace{
+			{
+				Title: "Main Site",
+				Collection: []Collection{
+					{
+						Href:   "http://www.test.com/blog",
+						Title:  "Blog entries",
+						Accept: []string{"application/atom+xml;type=entry"},
+						Categories: []Categories{
+							{Category: []Category{{Term: "tech"}}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := service.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	service.Workspace[0].Collection[0].Href = ""
+	if err := service.Validate(); err == nil {
+		t.Fatal("Validation should have failed with an empty Collection.Href.")
+	}
+}
+
+func TestCategoryDocumentValidation(t *testing.T) {
+	doc := CategoryDocument{
+		Categories: Categories{
+			Scheme:   "http://www.test.com/categories",
+			Category: []Category{{Term: "tech"}, {Term: "news"}},
+		},
+	}
+
+	if err := doc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	doc.Category[0].Term = ""
+	if err := doc.Validate(); err == nil {
+		t.Fatal("Validation should have failed with an empty Category.Term.")
+	}
+}
+
+func TestFeedPagingLinks(t *testing.T) {
+	feed := Feed{
+		Link: []Link{
+			{Rel: "next", Href: "http://www.test.com/blog?page=2"},
+			{Rel: "previous", Href: "http://www.test.com/blog?page=0"},
+			{Rel: "first", Href: "http://www.test.com/blog?page=0"},
+			{Rel: "last", Href: "http://www.test.com/blog?page=9"},
+		},
+	}
+
+	if feed.NextLink() == nil || feed.NextLink().Href != "http://www.test.com/blog?page=2" {
+		t.Fatal("NextLink did not return the expected link.")
+	}
+	if feed.PrevLink() == nil || feed.PrevLink().Href != "http://www.test.com/blog?page=0" {
+		t.Fatal("PrevLink did not return the expected link.")
+	}
+	if feed.FirstLink() == nil || feed.LastLink() == nil {
+		t.Fatal("FirstLink or LastLink did not return the expected link.")
+	}
+
+	feed.Link = append(feed.Link, Link{Rel: "next", Href: "http://www.test.com/blog?page=3"})
+	feed.ID = "http://www.test.com/blog"
+	feed.Title = "Test feed"
+	feed.Updated = "2006-11-04T09:11:03-08:00"
+	feed.Author = []Person{{Name: "John Doe"}}
+
+	if err := feed.Validate(); err == nil {
+		t.Fatal("Validation should have failed with two rel=\"next\" links.")
+	}
+}
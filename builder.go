@@ -0,0 +1,196 @@
+package atom
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// FeedBuilder builds a Feed fluently. Each Add method validates the piece
+// it was given immediately, so a mistake is reported at the call that
+// introduced it rather than surfacing later, possibly far away, at Build.
+type FeedBuilder struct {
+	feed *Feed
+	err  error
+}
+
+// NewFeed starts a FeedBuilder for a feed with the given id, title and
+// updated time.
+func NewFeed(id, title string, updated time.Time) *FeedBuilder {
+	return &FeedBuilder{
+		feed: &Feed{
+			XMLName:   xml.Name{Space: atomNS, Local: "feed"},
+			ID:        id,
+			Title:     title,
+			Updated:   Time(updated),
+			UpdatedAt: updated,
+		},
+	}
+}
+
+// AddAuthor appends an author to the feed.
+func (b *FeedBuilder) AddAuthor(name, uri, email string) *FeedBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	person := Person{Name: name, URI: uri, Email: email}
+	if err := person.validate(); err != nil {
+		b.err = err
+		return b
+	}
+
+	b.feed.Author = append(b.feed.Author, person)
+	return b
+}
+
+// AddLink appends a link to the feed.
+func (b *FeedBuilder) AddLink(link Link) *FeedBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	if err := link.validate(DefaultOptions); err != nil {
+		b.err = err
+		return b
+	}
+
+	b.feed.Link = append(b.feed.Link, link)
+	return b
+}
+
+// AddCategory appends a category to the feed.
+func (b *FeedBuilder) AddCategory(category Category) *FeedBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	if err := category.validate(DefaultOptions); err != nil {
+		b.err = err
+		return b
+	}
+
+	b.feed.Category = append(b.feed.Category, category)
+	return b
+}
+
+// AddEntry appends an entry built by EntryBuilder.Build to the feed.
+func (b *FeedBuilder) AddEntry(entry *Entry) *FeedBuilder {
+	if b.err != nil || entry == nil {
+		return b
+	}
+
+	if err := entry.Validate(); err != nil {
+		b.err = err
+		return b
+	}
+
+	b.feed.Entry = append(b.feed.Entry, *entry)
+	return b
+}
+
+// Build returns the feed built so far, or the first error encountered by an
+// Add method, or a final Feed.Validate failure.
+func (b *FeedBuilder) Build() (*Feed, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if err := b.feed.Validate(); err != nil {
+		return nil, err
+	}
+
+	return b.feed, nil
+}
+
+// EntryBuilder builds an Entry fluently. Each Add/Set method validates the
+// piece it was given immediately, the same way FeedBuilder does.
+type EntryBuilder struct {
+	entry *Entry
+	err   error
+}
+
+// NewEntry starts an EntryBuilder for an entry with the given id, title and
+// updated time.
+func NewEntry(id, title string, updated time.Time) *EntryBuilder {
+	return &EntryBuilder{
+		entry: &Entry{
+			XMLName:   xml.Name{Space: atomNS, Local: "entry"},
+			ID:        id,
+			Title:     title,
+			Updated:   Time(updated),
+			UpdatedAt: updated,
+		},
+	}
+}
+
+// AddAuthor appends an author to the entry.
+func (b *EntryBuilder) AddAuthor(name, uri, email string) *EntryBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	person := Person{Name: name, URI: uri, Email: email}
+	if err := person.validate(); err != nil {
+		b.err = err
+		return b
+	}
+
+	b.entry.Author = append(b.entry.Author, person)
+	return b
+}
+
+// AddLink appends a link to the entry.
+func (b *EntryBuilder) AddLink(link Link) *EntryBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	if err := link.validate(DefaultOptions); err != nil {
+		b.err = err
+		return b
+	}
+
+	b.entry.Link = append(b.entry.Link, link)
+	return b
+}
+
+// SetContent sets the entry's content to body, with the given MIME type.
+func (b *EntryBuilder) SetContent(body, mimeType string) *EntryBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	content := &Text{Type: mimeType, Body: body}
+	if err := content.validate(true, DefaultOptions); err != nil {
+		b.err = err
+		return b
+	}
+
+	b.entry.Content = content
+	return b
+}
+
+// SetPublished sets the entry's published time.
+func (b *EntryBuilder) SetPublished(published time.Time) *EntryBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	b.entry.Published = Time(published)
+	b.entry.PublishedAt = published
+	return b
+}
+
+// Build returns the entry built so far, or the first error encountered by
+// an Add/Set method, or a final Entry.Validate failure.
+func (b *EntryBuilder) Build() (*Entry, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if err := b.entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	return b.entry, nil
+}
@@ -0,0 +1,72 @@
+package atom
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestFeedBuilder(t *testing.T) {
+	updated := time.Date(2006, 11, 4, 9, 11, 3, 0, time.FixedZone("", -8*60*60))
+
+	entry, err := NewEntry("http://www.test.com/blog/1", "Entry 1", updated).
+		AddAuthor("John Doe", "http://john.doe", "john@test.com").
+		SetContent("Hello, world!", "text/plain").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	feed, err := NewFeed("http://www.test.com/blog", "Test feed", updated).
+		AddAuthor("John Doe", "http://john.doe", "john@test.com").
+		AddLink(Link{Rel: "self", Href: "http://www.test.com/blog"}).
+		AddEntry(entry).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if feed.Updated != Time(updated) {
+		t.Fatal("FeedBuilder did not set Updated from the given time.")
+	}
+
+	if len(feed.Entry) != 1 || feed.Entry[0].Title != "Entry 1" {
+		t.Fatal("FeedBuilder did not add the built entry.")
+	}
+
+	if _, err := NewFeed("", "", updated).AddAuthor("", "not a url :://", "").Build(); err == nil {
+		t.Fatal("FeedBuilder should have failed to add an author with an invalid URI.")
+	}
+}
+
+func TestUpdatedAtMarshalRoundTrip(t *testing.T) {
+	updated := time.Date(2006, 11, 4, 9, 11, 3, 0, time.FixedZone("", -8*60*60))
+
+	feed := Feed{
+		ID:        "http://www.test.com/blog",
+		Title:     "Test feed",
+		UpdatedAt: updated,
+	}
+
+	if feed.Updated != "" {
+		t.Fatal("Updated should not be set until marshaling derives it from UpdatedAt.")
+	}
+
+	data, err := xml.Marshal(&feed)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var decoded Feed
+	if err := xml.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if decoded.Updated != Time(updated) || !decoded.UpdatedAt.Equal(updated) {
+		t.Fatal("UpdatedAt did not round-trip through marshal/unmarshal correctly.")
+	}
+}
@@ -65,7 +65,7 @@ func TimeParse(t TimeStr) (time.Time, error) {
 }
 
 // Category is the category that this feed or entry belongs to. Validation:
-//   - Term must be present and can't be empty.
+//   - Term must be non-empty under the RFC4287Strict profile.
 //   - Scheme if present must be a url.URL.
 type Category struct {
 	Common
@@ -77,6 +77,7 @@ type Category struct {
 
 // Generator is the generating agent for this feed. Validation:
 //   - if URI is present it must be a valid url.URL.
+//   - Under the RFC4287Strict profile, URI if present must also be absolute.
 type Generator struct {
 	Common
 
@@ -89,6 +90,8 @@ type Generator struct {
 // title="MP3" href="http://www.example.org/myaudiofile.mp3" hreflang="de"
 // length="1234" />. Validation:
 //   - Href must be a valid url.URL.
+//   - Under the RFC4287Strict profile, Length if present must be a positive
+//     integer.
 type Link struct {
 	Common
 
@@ -109,27 +112,60 @@ type Source struct {
 }
 
 // Entry is a single entry inside a Feed. Validation:
-//   - ID can't be empty.
-//   - Can't contain more than one link element of rel="alternate" and the same values.
+//   - ID can't be empty, and under the RFC4287Strict profile must be an
+//     absolute IRI.
+//   - Can't contain more than one link element of rel="alternate", "next",
+//     "previous", "first" or "last". Under the RFC4287Strict profile, only
+//     links that also share the same type/hreflang combination count.
 //   - Title can't be empty.
 //   - Updated can't be empty and must be a valid time string.
+//   - Media if present must validate, see Media.
 type Entry struct {
 	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom entry"`
 	Common
 
 	// Metadata
-	Author      []Person   `xml:"author"`
-	Category    []Category `xml:"category,omitempty"`
-	Content     *Text      `xml:"content,omitempty"`
-	Contributor []Person   `xml:"contributor,omitempty"`
-	ID          string     `xml:"id"`
-	Link        []Link     `xml:"link,omitempty"`
-	Published   TimeStr    `xml:"published,omitempty"`
-	Rights      string     `xml:"rights,omitempty"`
-	Source      *Source    `xml:"source,omitempty"`
-	Summary     *Text      `xml:"summary,omitempty"`
-	Title       string     `xml:"title"`
-	Updated     TimeStr    `xml:"updated"`
+	Author   []Person   `xml:"author"`
+	Category []Category `xml:"category,omitempty"`
+	// Content is explicitly namespaced (unlike its siblings) so it isn't
+	// ambiguous with the Media RSS <media:content> element promoted below.
+	Content     *Text    `xml:"http://www.w3.org/2005/Atom content,omitempty"`
+	Contributor []Person `xml:"contributor,omitempty"`
+	ID          string   `xml:"id"`
+	Link        []Link   `xml:"link,omitempty"`
+
+	// Media is the Yahoo Media RSS extension. It is anonymous so that its
+	// fields are promoted onto Entry: MRSS lets <media:content> and
+	// <media:thumbnail> appear directly on the entry or wrapped in a
+	// <media:group>, and Media captures both shapes, see Media.
+	*Media
+	Rights  string  `xml:"rights,omitempty"`
+	Source  *Source `xml:"source,omitempty"`
+	Summary *Text   `xml:"summary,omitempty"`
+	Title   string  `xml:"title"`
+
+	// Published is the raw RFC3339 time string.
+	//
+	// Deprecated: set and read PublishedAt instead; Published is kept for
+	// compatibility and is kept in sync with PublishedAt on marshal and
+	// unmarshal.
+	Published TimeStr `xml:"published,omitempty"`
+
+	// PublishedAt is the entry's initial publish time. It is marshaled to
+	// and parsed from Published automatically.
+	PublishedAt time.Time `xml:"-"`
+
+	// Updated is the raw RFC3339 time string.
+	//
+	// Deprecated: set and read UpdatedAt instead; Updated is kept for
+	// compatibility and is kept in sync with UpdatedAt on marshal and
+	// unmarshal.
+	Updated TimeStr `xml:"updated"`
+
+	// UpdatedAt is the entry's last-modified time. It is marshaled to and
+	// parsed from Updated automatically, so callers no longer need to
+	// hand-format an RFC3339 string.
+	UpdatedAt time.Time `xml:"-"`
 
 	// Custom elements
 	Extension []Extension `xml:",any,omitempty"`
@@ -137,12 +173,18 @@ type Entry struct {
 
 // Feed is the top level ATOM syndication element. Validation:
 //   - Author can't be empty, unless present in all entry elements.
-//   - Can't contain more than one link element of rel="alternate" and the same values.
+//   - Can't contain more than one link element of rel="alternate", "next",
+//     "previous", "first" or "last". Under the RFC4287Strict profile, only
+//     links that also share the same type/hreflang combination count.
 //   - Title can't be empty.
 //   - Icon if present must be a valid url.URL.
-//   - ID can't be empty.
+//   - ID can't be empty, and under the RFC4287Strict profile must be an
+//     absolute IRI.
 //   - Logo if present must be a valid url.URL.
 //   - Updated can't be empty and must be a valid time string.
+//
+// Validate applies the Lenient profile. Use ValidateWith to apply a
+// different ValidationOptions profile, e.g. RFC4287Strict.
 type Feed struct {
 	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
 	Common
@@ -159,11 +201,27 @@ type Feed struct {
 	Rights      string     `xml:"rights,omitempty"`
 	Subtitle    string     `xml:"subtitle,omitempty"`
 	Title       string     `xml:"title"`
-	Updated     TimeStr    `xml:"updated"`
+
+	// Updated is the raw RFC3339 time string.
+	//
+	// Deprecated: set and read UpdatedAt instead; Updated is kept for
+	// compatibility and is kept in sync with UpdatedAt on marshal and
+	// unmarshal.
+	Updated TimeStr `xml:"updated"`
+
+	// UpdatedAt is the feed's last-modified time. It is marshaled to and
+	// parsed from Updated automatically, so callers no longer need to
+	// hand-format an RFC3339 string.
+	UpdatedAt time.Time `xml:"-"`
 
 	// Entries
 	Entry []Entry `xml:"entry"`
 
 	// Custom elements
 	Extension []Extension `xml:",any,omitempty"`
+
+	// SourceVersion records the Atom version this Feed was decoded from by
+	// Parse ("1.0" or "0.3"). It is never present on the wire and is not
+	// marshaled.
+	SourceVersion string `xml:"-"`
 }
@@ -0,0 +1,175 @@
+package atom
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// mrssNS is the Yahoo Media RSS namespace (http://search.yahoo.com/mrss/)
+// used by the <media:*> extension elements embedded in an Entry.
+const mrssNS = "http://search.yahoo.com/mrss/"
+
+// mediaValidMedium lists the values the MRSS spec allows for
+// MediaContent.Medium.
+var mediaValidMedium = map[string]bool{
+	"image":      true,
+	"audio":      true,
+	"video":      true,
+	"document":   true,
+	"executable": true,
+}
+
+// MediaContent is a <media:content> element, i.e. <media:content
+// url="http://www.example.com/movie.mov" medium="video" isDefault="true" />.
+// Validation:
+//   - URL must be a valid url.URL.
+//   - Medium if present must be one of: image, audio, video, document,
+//     executable.
+type MediaContent struct {
+	URL       string `xml:"url,attr"`
+	Type      string `xml:"type,attr,omitempty"`
+	FileSize  int64  `xml:"fileSize,attr,omitempty"`
+	Medium    string `xml:"medium,attr,omitempty"`
+	Duration  int    `xml:"duration,attr,omitempty"`
+	Width     int    `xml:"width,attr,omitempty"`
+	Height    int    `xml:"height,attr,omitempty"`
+	IsDefault bool   `xml:"isDefault,attr,omitempty"`
+}
+
+// Validate a media:content element.
+func (content *MediaContent) validate() error {
+	var errs = new(ValidationError)
+
+	if content.URL == "" {
+		errs.Add("MediaContent.URL can't be empty.")
+	} else if _, err := url.Parse(content.URL); err != nil {
+		errs.AddErr(err)
+	}
+
+	if content.Medium != "" && !mediaValidMedium[content.Medium] {
+		errs.Add("MediaContent.Medium must be one of: image, audio, video, document, executable.")
+	}
+
+	return errs.NilIfEmpty()
+}
+
+// MediaThumbnail is a <media:thumbnail> element.
+type MediaThumbnail struct {
+	URL    string `xml:"url,attr"`
+	Width  int    `xml:"width,attr,omitempty"`
+	Height int    `xml:"height,attr,omitempty"`
+	Time   string `xml:"time,attr,omitempty"`
+}
+
+// MediaDescription is a <media:description> element.
+type MediaDescription struct {
+	Type string `xml:"type,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+// MediaPeerLink is a <media:peerLink> element, used to reference a
+// peer-to-peer location for the media object, e.g. a BitTorrent link.
+type MediaPeerLink struct {
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+// MediaCredit is a <media:credit> element identifying an entity that
+// contributed to the media object, e.g. <media:credit role="producer">John
+// Doe</media:credit>.
+type MediaCredit struct {
+	Role   string `xml:"role,attr,omitempty"`
+	Scheme string `xml:"scheme,attr,omitempty"`
+	Text   string `xml:",chardata"`
+}
+
+// MediaGroup is a <media:group> element, used to bundle several
+// content/thumbnail/etc elements that are renditions of the same
+// underlying media object (e.g. different encodings of one video).
+type MediaGroup struct {
+	Contents     []MediaContent     `xml:"http://search.yahoo.com/mrss/ content,omitempty"`
+	Thumbnails   []MediaThumbnail   `xml:"http://search.yahoo.com/mrss/ thumbnail,omitempty"`
+	Descriptions []MediaDescription `xml:"http://search.yahoo.com/mrss/ description,omitempty"`
+	PeerLinks    []MediaPeerLink    `xml:"http://search.yahoo.com/mrss/ peerLink,omitempty"`
+	Credits      []MediaCredit      `xml:"http://search.yahoo.com/mrss/ credit,omitempty"`
+}
+
+// Media is the Yahoo Media RSS (http://search.yahoo.com/mrss/) extension on
+// an Entry. MRSS allows <media:content>/<media:thumbnail>/etc to appear
+// directly on the entry (common for a single podcast or video enclosure) or
+// wrapped in a <media:group> (common when an entry offers several
+// renditions of the same item); Media captures both shapes, so callers and
+// EnclosureLinks don't need to know which one a given feed used.
+// Validation:
+//   - Every MediaContent, whether on Media or Media.Group, must validate,
+//     see MediaContent.
+//   - At most one MediaContent across both may have IsDefault set.
+type Media struct {
+	Group        *MediaGroup        `xml:"http://search.yahoo.com/mrss/ group,omitempty"`
+	Contents     []MediaContent     `xml:"http://search.yahoo.com/mrss/ content,omitempty"`
+	Thumbnails   []MediaThumbnail   `xml:"http://search.yahoo.com/mrss/ thumbnail,omitempty"`
+	Descriptions []MediaDescription `xml:"http://search.yahoo.com/mrss/ description,omitempty"`
+	PeerLinks    []MediaPeerLink    `xml:"http://search.yahoo.com/mrss/ peerLink,omitempty"`
+	Credits      []MediaCredit      `xml:"http://search.yahoo.com/mrss/ credit,omitempty"`
+}
+
+// allContents returns every MediaContent belonging to media, whether given
+// directly or nested inside Group.
+func (media *Media) allContents() []MediaContent {
+	if media.Group == nil {
+		return media.Contents
+	}
+
+	return append(append([]MediaContent(nil), media.Contents...), media.Group.Contents...)
+}
+
+// Validate a media extension, covering both its own and its Group's content.
+func (media *Media) validate() error {
+	var errs = new(ValidationError)
+
+	foundDefault := false
+	for _, content := range media.allContents() {
+		errs.Merge(content.validate())
+
+		if content.IsDefault {
+			if foundDefault {
+				errs.Add("Only one Media.Contents element can have IsDefault set.")
+			} else {
+				foundDefault = true
+			}
+		}
+	}
+
+	return errs.NilIfEmpty()
+}
+
+// EnclosureLinks synthesizes RFC 4287 rel="enclosure" Link values from the
+// entry's Media content, for consumers that only understand plain Atom
+// links and have no knowledge of the Media RSS extension.
+func (entry *Entry) EnclosureLinks() []Link {
+	if entry.Media == nil {
+		return nil
+	}
+
+	contents := entry.Media.allContents()
+	if len(contents) == 0 {
+		return nil
+	}
+
+	links := make([]Link, 0, len(contents))
+	for _, content := range contents {
+		link := Link{
+			Href: content.URL,
+			Rel:  "enclosure",
+			Type: content.Type,
+		}
+
+		if content.FileSize > 0 {
+			link.Length = strconv.FormatInt(content.FileSize, 10)
+		}
+
+		links = append(links, link)
+	}
+
+	return links
+}
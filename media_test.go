@@ -0,0 +1,96 @@
+package atom
+
+import "testing"
+
+func TestMediaValidation(t *testing.T) {
+	entry := Entry{
+		ID:      "http://www.test.com/blog/1",
+		Title:   "Entry 1",
+		Updated: "2006-11-04T09:11:03-08:00",
+		Media: &Media{
+			Contents: []MediaContent{
+				{URL: "http://www.test.com/movie.mov", Medium: "video", IsDefault: true},
+				{URL: "http://www.test.com/movie.ogg", Medium: "video"},
+			},
+		},
+	}
+
+	if err := entry.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	entry.Media.Contents[1].IsDefault = true
+	if err := entry.Validate(); err == nil {
+		t.Fatal("Validation should have failed with two default media contents.")
+	}
+
+	entry.Media.Contents[1].IsDefault = false
+	entry.Media.Contents[1].Medium = "bogus"
+	if err := entry.Validate(); err == nil {
+		t.Fatal("Validation should have failed with an invalid medium.")
+	}
+}
+
+func TestMediaGroupValidation(t *testing.T) {
+	entry := Entry{
+		ID:      "http://www.test.com/blog/1",
+		Title:   "Entry 1",
+		Updated: "2006-11-04T09:11:03-08:00",
+		Media: &Media{
+			Contents: []MediaContent{
+				{URL: "http://www.test.com/movie.mov", Medium: "video", IsDefault: true},
+			},
+			Group: &MediaGroup{
+				Contents: []MediaContent{
+					{URL: "http://www.test.com/movie.ogg", Medium: "video"},
+				},
+			},
+		},
+	}
+
+	if err := entry.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	entry.Media.Group.Contents[0].IsDefault = true
+	if err := entry.Validate(); err == nil {
+		t.Fatal("Validation should have failed with a default content both directly on Media and inside Group.")
+	}
+}
+
+func TestEnclosureLinks(t *testing.T) {
+	entry := Entry{
+		Media: &Media{
+			Contents: []MediaContent{
+				{URL: "http://www.test.com/movie.mov", Type: "video/quicktime", FileSize: 12345},
+			},
+		},
+	}
+
+	links := entry.EnclosureLinks()
+	if len(links) != 1 ||
+		links[0].Href != "http://www.test.com/movie.mov" ||
+		links[0].Rel != "enclosure" ||
+		links[0].Type != "video/quicktime" ||
+		links[0].Length != "12345" {
+		t.Fatal("EnclosureLinks did not synthesize the expected link.")
+	}
+}
+
+func TestEnclosureLinksFromGroup(t *testing.T) {
+	entry := Entry{
+		Media: &Media{
+			Group: &MediaGroup{
+				Contents: []MediaContent{
+					{URL: "http://www.test.com/movie.mov", Type: "video/quicktime"},
+					{URL: "http://www.test.com/movie.ogg", Type: "video/ogg"},
+				},
+			},
+		},
+	}
+
+	links := entry.EnclosureLinks()
+	if len(links) != 2 {
+		t.Fatal("EnclosureLinks should include content nested inside Media.Group.")
+	}
+}
@@ -0,0 +1,37 @@
+package atom
+
+// linkByRel returns the feed's first Link with the given rel, or nil if
+// none is present.
+func (feed *Feed) linkByRel(rel string) *Link {
+	for i := range feed.Link {
+		if feed.Link[i].Rel == rel {
+			return &feed.Link[i]
+		}
+	}
+
+	return nil
+}
+
+// NextLink returns the feed's rel="next" Link, as defined by RFC 5005 (Feed
+// Paging and Archiving), or nil if none is present.
+func (feed *Feed) NextLink() *Link {
+	return feed.linkByRel("next")
+}
+
+// PrevLink returns the feed's rel="previous" Link, as defined by RFC 5005,
+// or nil if none is present.
+func (feed *Feed) PrevLink() *Link {
+	return feed.linkByRel("previous")
+}
+
+// FirstLink returns the feed's rel="first" Link, as defined by RFC 5005, or
+// nil if none is present.
+func (feed *Feed) FirstLink() *Link {
+	return feed.linkByRel("first")
+}
+
+// LastLink returns the feed's rel="last" Link, as defined by RFC 5005, or
+// nil if none is present.
+func (feed *Feed) LastLink() *Link {
+	return feed.linkByRel("last")
+}
@@ -0,0 +1,182 @@
+package atom
+
+import (
+	"encoding/xml"
+	"io"
+
+	"golang.org/x/net/html/charset"
+)
+
+// StreamDecoder reads a Feed one Entry at a time instead of unmarshaling the
+// whole document into memory, for feeds with very large entry counts (the
+// paged CMIS/AtomPub responses in the fixtures are a good example). Feed is
+// populated with everything except Entry as soon as the feed's metadata has
+// been read; Next then yields each Entry in turn.
+type StreamDecoder struct {
+	decoder *xml.Decoder
+
+	// Feed holds the feed's metadata, i.e. everything but Entry. It is
+	// populated once the first Next call returns, and Feed.Entry is
+	// always left empty: entries are only ever delivered through Next.
+	Feed Feed
+
+	validate bool
+	done     bool
+}
+
+// NewStreamDecoder creates a StreamDecoder reading from r. Character-set
+// detection works the same way as with a plain xml.Decoder.
+func NewStreamDecoder(r io.Reader) *StreamDecoder {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = charset.NewReaderLabel
+
+	return &StreamDecoder{decoder: decoder}
+}
+
+// ValidateEntries turns on per-entry validation: Next will call
+// Entry.Validate on each entry it decodes and return the validation error in
+// place of the entry.
+func (sd *StreamDecoder) ValidateEntries(validate bool) {
+	sd.validate = validate
+}
+
+// Next returns the next Entry in the feed, or io.EOF once the feed is
+// exhausted.
+func (sd *StreamDecoder) Next() (*Entry, error) {
+	if sd.done {
+		return nil, io.EOF
+	}
+
+	for {
+		tok, err := sd.decoder.Token()
+		if err != nil {
+			sd.done = true
+			return nil, err
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		if start.Name.Local == "feed" {
+			sd.decodeFeedStart(start)
+			continue
+		}
+
+		if start.Name.Local != "entry" {
+			if err := sd.decodeFeedChild(start); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var entry Entry
+		if err := sd.decoder.DecodeElement(&entry, &start); err != nil {
+			return nil, err
+		}
+
+		if sd.validate {
+			if err := entry.Validate(); err != nil {
+				return nil, err
+			}
+		}
+
+		return &entry, nil
+	}
+}
+
+// decodeFeedStart records the <feed> element's own name and xml:base/
+// xml:lang attributes.
+func (sd *StreamDecoder) decodeFeedStart(start xml.StartElement) {
+	sd.Feed.XMLName = start.Name
+
+	for _, attr := range start.Attr {
+		if attr.Name.Space != "http://www.w3.org/XML/1998/namespace" {
+			continue
+		}
+
+		switch attr.Name.Local {
+		case "base":
+			sd.Feed.Base = attr.Value
+		case "lang":
+			sd.Feed.Lang = attr.Value
+		}
+	}
+}
+
+// decodeFeedChild decodes a single feed-level child element (anything other
+// than <entry>) into the matching Feed field.
+func (sd *StreamDecoder) decodeFeedChild(start xml.StartElement) error {
+	switch start.Name.Local {
+	case "author":
+		var person Person
+		if err := sd.decoder.DecodeElement(&person, &start); err != nil {
+			return err
+		}
+		sd.Feed.Author = append(sd.Feed.Author, person)
+
+	case "category":
+		var category Category
+		if err := sd.decoder.DecodeElement(&category, &start); err != nil {
+			return err
+		}
+		sd.Feed.Category = append(sd.Feed.Category, category)
+
+	case "contributor":
+		var person Person
+		if err := sd.decoder.DecodeElement(&person, &start); err != nil {
+			return err
+		}
+		sd.Feed.Contributor = append(sd.Feed.Contributor, person)
+
+	case "generator":
+		var generator Generator
+		if err := sd.decoder.DecodeElement(&generator, &start); err != nil {
+			return err
+		}
+		sd.Feed.Generator = &generator
+
+	case "icon":
+		return sd.decoder.DecodeElement(&sd.Feed.Icon, &start)
+
+	case "id":
+		return sd.decoder.DecodeElement(&sd.Feed.ID, &start)
+
+	case "link":
+		var link Link
+		if err := sd.decoder.DecodeElement(&link, &start); err != nil {
+			return err
+		}
+		sd.Feed.Link = append(sd.Feed.Link, link)
+
+	case "logo":
+		return sd.decoder.DecodeElement(&sd.Feed.Logo, &start)
+
+	case "rights":
+		return sd.decoder.DecodeElement(&sd.Feed.Rights, &start)
+
+	case "subtitle":
+		return sd.decoder.DecodeElement(&sd.Feed.Subtitle, &start)
+
+	case "title":
+		return sd.decoder.DecodeElement(&sd.Feed.Title, &start)
+
+	case "updated":
+		if err := sd.decoder.DecodeElement(&sd.Feed.Updated, &start); err != nil {
+			return err
+		}
+		if t, err := TimeParse(sd.Feed.Updated); err == nil {
+			sd.Feed.UpdatedAt = t
+		}
+
+	default:
+		var extension Extension
+		if err := sd.decoder.DecodeElement(&extension, &start); err != nil {
+			return err
+		}
+		sd.Feed.Extension = append(sd.Feed.Extension, extension)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package atom
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStreamDecoder(t *testing.T) {
+	sd := NewStreamDecoder(strings.NewReader(`<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+	<title>Test feed</title>
+	<id>http://www.test.com/blog</id>
+	<author><name>John Doe</name></author>
+	<updated>2006-11-04T09:11:03-08:00</updated>
+	<entry>
+		<title>Entry 1</title>
+		<id>http://www.test.com/blog/1</id>
+		<updated>2006-11-04T09:11:03-08:00</updated>
+	</entry>
+	<entry>
+		<title>Entry 2</title>
+		<id>http://www.test.com/blog/2</id>
+		<updated>2006-11-04T09:11:03-08:00</updated>
+	</entry>
+</feed>`))
+	sd.ValidateEntries(true)
+
+	var entries []*Entry
+	for {
+		entry, err := sd.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+			return
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) != 2 || entries[0].Title != "Entry 1" || entries[1].Title != "Entry 2" {
+		t.Fatal("StreamDecoder did not yield the expected entries.")
+	}
+
+	if sd.Feed.Title != "Test feed" || sd.Feed.ID != "http://www.test.com/blog" {
+		t.Fatal("StreamDecoder did not populate Feed metadata correctly.")
+	}
+
+	if len(sd.Feed.Author) != 1 || sd.Feed.Author[0].Name != "John Doe" {
+		t.Fatal("StreamDecoder did not populate Feed.Author correctly.")
+	}
+
+	if len(sd.Feed.Entry) != 0 {
+		t.Fatal("StreamDecoder should never populate Feed.Entry.")
+	}
+}
@@ -0,0 +1,92 @@
+package atom
+
+import "encoding/xml"
+
+// feedAlias has Feed's exact shape but none of its methods, so it can be
+// marshaled/unmarshaled with encoding/xml's normal reflection-based
+// machinery from inside Feed's own MarshalXML/UnmarshalXML without
+// recursing.
+type feedAlias Feed
+
+// UnmarshalXML decodes a feed and then populates UpdatedAt from Updated, so
+// callers that only look at UpdatedAt still see the right time.
+func (feed *Feed) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var aux feedAlias
+	if err := d.DecodeElement(&aux, &start); err != nil {
+		return err
+	}
+
+	if t, err := TimeParse(aux.Updated); err == nil {
+		aux.UpdatedAt = t
+	}
+
+	*feed = Feed(aux)
+	return nil
+}
+
+// MarshalXML encodes the feed, re-deriving Updated from UpdatedAt first when
+// UpdatedAt has been set, so callers that only set UpdatedAt still produce a
+// correct <updated> element.
+func (feed *Feed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	aux := feedAlias(*feed)
+	if !aux.UpdatedAt.IsZero() {
+		aux.Updated = Time(aux.UpdatedAt)
+	}
+
+	// Implementing Marshaler bypasses the reflection path that would
+	// otherwise read the XMLName field's struct tag, so a Feed built
+	// without an explicit XMLName (the documented historical usage) would
+	// otherwise be encoded as <Feed> instead of <feed>.
+	if start.Name.Local == "" || start.Name.Local == "Feed" {
+		start.Name = xml.Name{Space: atomNS, Local: "feed"}
+	}
+
+	return e.EncodeElement(aux, start)
+}
+
+// entryAlias has Entry's exact shape but none of its methods, for the same
+// reason feedAlias exists.
+type entryAlias Entry
+
+// UnmarshalXML decodes an entry and then populates UpdatedAt/PublishedAt
+// from Updated/Published.
+func (entry *Entry) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var aux entryAlias
+	if err := d.DecodeElement(&aux, &start); err != nil {
+		return err
+	}
+
+	if t, err := TimeParse(aux.Updated); err == nil {
+		aux.UpdatedAt = t
+	}
+
+	if aux.Published != "" {
+		if t, err := TimeParse(aux.Published); err == nil {
+			aux.PublishedAt = t
+		}
+	}
+
+	*entry = Entry(aux)
+	return nil
+}
+
+// MarshalXML encodes the entry, re-deriving Updated/Published from
+// UpdatedAt/PublishedAt first when they have been set.
+func (entry *Entry) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	aux := entryAlias(*entry)
+	if !aux.UpdatedAt.IsZero() {
+		aux.Updated = Time(aux.UpdatedAt)
+	}
+	if !aux.PublishedAt.IsZero() {
+		aux.Published = Time(aux.PublishedAt)
+	}
+
+	// See the matching comment in Feed.MarshalXML: without this, an Entry
+	// built without an explicit XMLName would be encoded as <Entry>
+	// instead of <entry>.
+	if start.Name.Local == "" || start.Name.Local == "Entry" {
+		start.Name = xml.Name{Space: atomNS, Local: "entry"}
+	}
+
+	return e.EncodeElement(aux, start)
+}
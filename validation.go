@@ -1,27 +1,85 @@
 package atom
 
 import (
+	"encoding/xml"
+	"errors"
 	"net/mail"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// Severity classifies how serious a validation Issue is.
+type Severity int
+
+const (
+	// SeverityError marks an issue that makes the document non-conformant.
+	SeverityError Severity = iota
+	// SeverityWarning marks an issue worth surfacing that does not by
+	// itself make the document non-conformant.
+	SeverityWarning
+)
+
+// Issue is a single validation finding. Path identifies the field it
+// applies to (e.g. "Feed.ID"), Rule names the check that failed (e.g.
+// "absolute-iri"), and Message is the human-readable description also
+// included in ValidationError.Error(). Path and Rule are only populated for
+// issues raised by ValidateWith's profile-specific rules; issues raised by
+// the base rules Validate has always enforced carry Message only.
+type Issue struct {
+	Path     string
+	Rule     string
+	Message  string
+	Severity Severity
+}
+
+// Profile selects which set of rules ValidateWith enforces.
+type Profile int
+
+const (
+	// Lenient enforces only the rules Validate has always enforced. This
+	// is the default.
+	Lenient Profile = iota
+	// RFC4287Strict additionally enforces rules from
+	// https://tools.ietf.org/html/rfc4287 that Lenient does not, such as
+	// atom:id being an absolute IRI.
+	RFC4287Strict
+	// AtomPub is for validating the Service and CategoryDocument types in
+	// atompub.go; it enforces the same rules as Lenient.
+	AtomPub
+)
+
+// ValidationOptions controls which rules ValidateWith applies.
+type ValidationOptions struct {
+	Profile Profile
+}
+
+// DefaultOptions is used by Validate and matches this package's historical
+// behavior.
+var DefaultOptions = &ValidationOptions{Profile: Lenient}
+
 // ValidationError is an error encountered while trying to validate an
 // ATOM feed.
 type ValidationError struct {
-	issues []string
+	issues []Issue
 }
 
 func (err *ValidationError) Error() string {
 	result := "An ATOM validation error occured:\n"
 
 	for _, issue := range err.issues {
-		result += "- " + issue + "\n"
+		result += "- " + issue.Message + "\n"
 	}
 
 	return result
 }
 
+// Issues returns the structured issues collected in this error.
+func (err *ValidationError) Issues() []Issue {
+	return err.issues
+}
+
 // Merge validate errors. other must be a *ValidationError.
 func (err *ValidationError) Merge(other error) {
 	if other == nil {
@@ -33,13 +91,19 @@ func (err *ValidationError) Merge(other error) {
 
 // Add Adds a string to the list of issues
 func (err *ValidationError) Add(issue string) {
-	err.issues = append(err.issues, issue)
+	err.issues = append(err.issues, Issue{Message: issue, Severity: SeverityError})
 }
 
 // AddErr Adds an error to the list of validaiton issues. It converts it to a
 // string first.
 func (err *ValidationError) AddErr(issue error) {
-	err.issues = append(err.issues, issue.Error())
+	err.issues = append(err.issues, Issue{Message: issue.Error(), Severity: SeverityError})
+}
+
+// AddIssue adds a structured, path/rule-tagged issue to the list, at
+// SeverityError.
+func (err *ValidationError) AddIssue(path, rule, message string) {
+	err.issues = append(err.issues, Issue{Path: path, Rule: rule, Message: message, Severity: SeverityError})
 }
 
 // NilIfEmpty returns nil if there are no issues in the ValidationError,
@@ -65,8 +129,51 @@ func validateCommon(common Common) error {
 	return errs.NilIfEmpty()
 }
 
+// isXHTMLType returns whether t identifies XHTML content, either as the
+// short Atom 0.3/Text.Type form ("xhtml") or the MIME form used by
+// Entry.Content ("application/xhtml+xml").
+func isXHTMLType(t string) bool {
+	return t == "xhtml" || t == "application/xhtml+xml"
+}
+
+// validateXHTMLDiv checks that body contains exactly one top-level <div> in
+// the XHTML namespace, as RFC 4287 section 3.1.1.3 requires of a
+// type="xhtml" Text construct. A top-level <div> that carries no namespace
+// within body itself is counted as well.
+func validateXHTMLDiv(body string) error {
+	decoder := xml.NewDecoder(strings.NewReader("<wrap>" + body + "</wrap>"))
+
+	count := 0
+	depth := 0
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 2 && el.Name.Local == "div" &&
+				(el.Name.Space == "" || el.Name.Space == "http://www.w3.org/1999/xhtml") {
+				count++
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	if count != 1 {
+		return errBadXHTMLDiv
+	}
+
+	return nil
+}
+
+var errBadXHTMLDiv = errors.New(`Text with type="xhtml" must contain exactly one <div> in the XHTML namespace.`)
+
 // Validate the text ATOM structure.
-func (text *Text) validate(isContent bool) error {
+func (text *Text) validate(isContent bool, opts *ValidationOptions) error {
 	var errs = new(ValidationError)
 
 	errs.Merge(validateCommon(text.Common))
@@ -85,6 +192,12 @@ func (text *Text) validate(isContent bool) error {
 		}
 	}
 
+	if opts.Profile == RFC4287Strict && isXHTMLType(text.Type) {
+		if err := validateXHTMLDiv(text.Body); err != nil {
+			errs.AddIssue("Text.Body", "xhtml-div", err.Error())
+		}
+	}
+
 	return errs.NilIfEmpty()
 }
 
@@ -121,7 +234,7 @@ func (timeStr *TimeStr) validate() error {
 }
 
 // Validate an ATOM category.
-func (category *Category) validate() error {
+func (category *Category) validate(opts *ValidationOptions) error {
 	var errs = new(ValidationError)
 
 	errs.Merge(validateCommon(category.Common))
@@ -132,18 +245,25 @@ func (category *Category) validate() error {
 		}
 	}
 
+	if opts.Profile == RFC4287Strict && category.Term == "" {
+		errs.AddIssue("Category.Term", "non-empty", "Category.Term must be non-empty.")
+	}
+
 	return errs.NilIfEmpty()
 }
 
 // Validate an ATOM generator.
-func (generator *Generator) validate() error {
+func (generator *Generator) validate(opts *ValidationOptions) error {
 	var errs = new(ValidationError)
 
 	errs.Merge(validateCommon(generator.Common))
 
 	if generator.URI != "" {
-		if _, err := url.Parse(generator.URI); err != nil {
+		u, err := url.Parse(generator.URI)
+		if err != nil {
 			errs.AddErr(err)
+		} else if opts.Profile == RFC4287Strict && !u.IsAbs() {
+			errs.AddIssue("Generator.URI", "absolute", "Generator.URI must be an absolute URI.")
 		}
 	}
 
@@ -151,7 +271,7 @@ func (generator *Generator) validate() error {
 }
 
 // Validate an ATOM link.
-func (link *Link) validate() error {
+func (link *Link) validate(opts *ValidationOptions) error {
 	var errs = new(ValidationError)
 
 	errs.Merge(validateCommon(link.Common))
@@ -160,11 +280,85 @@ func (link *Link) validate() error {
 		errs.AddErr(err)
 	}
 
+	if opts.Profile == RFC4287Strict && link.Length != "" {
+		if n, err := strconv.Atoi(link.Length); err != nil || n <= 0 {
+			errs.AddIssue("Link.Length", "positive-integer", "Link.Length must be a positive integer.")
+		}
+	}
+
 	return errs.NilIfEmpty()
 }
 
-// Validate validates an entry document
+// relUniquenessRules are the rel values that may only appear once per Feed
+// or Entry under the Lenient profile: alternate per RFC 4287 section
+// 4.2.7.2, and the paging rels of RFC 5005.
+var relUniquenessRules = []string{"alternate", "next", "previous", "first", "last"}
+
+// validateLinkUniqueness enforces relUniquenessRules against links. Under
+// Lenient, at most one Link per tracked rel is allowed, matching this
+// package's historical behavior. Under RFC4287Strict, links are only
+// considered duplicates if they also share the same (type, hreflang), as
+// RFC 4287 actually specifies for rel="alternate".
+func validateLinkUniqueness(links []Link, owner string, opts *ValidationOptions, errs *ValidationError) {
+	tracked := map[string]bool{}
+	for _, rel := range relUniquenessRules {
+		tracked[rel] = true
+	}
+
+	type key struct {
+		rel, typ, lang string
+	}
+
+	seenKey := map[key]bool{}
+	seenRel := map[string]bool{}
+
+	for _, link := range links {
+		if !tracked[link.Rel] {
+			continue
+		}
+
+		if opts.Profile == RFC4287Strict {
+			k := key{link.Rel, link.Type, link.HrefLang}
+			if seenKey[k] {
+				errs.Add("Only one " + owner + ".Link with rel=\"" + link.Rel + "\" can exist for a given type/hreflang combination.")
+			}
+			seenKey[k] = true
+			continue
+		}
+
+		if seenRel[link.Rel] {
+			errs.Add("Only one " + owner + ".Link with rel=\"" + link.Rel + "\" can exist.")
+		}
+		seenRel[link.Rel] = true
+	}
+}
+
+// validateAbsoluteID checks that id is present and, under RFC4287Strict, an
+// absolute IRI rather than merely a parseable one.
+func validateAbsoluteID(id, path string, opts *ValidationOptions, errs *ValidationError) {
+	if id == "" {
+		errs.Add(path + " can't be empty.")
+		return
+	}
+
+	if opts.Profile != RFC4287Strict {
+		return
+	}
+
+	u, err := url.Parse(id)
+	if err != nil || !u.IsAbs() {
+		errs.AddIssue(path, "absolute-iri", path+" must be an absolute IRI.")
+	}
+}
+
+// Validate validates an entry document using DefaultOptions.
 func (entry *Entry) Validate() error {
+	return entry.ValidateWith(DefaultOptions)
+}
+
+// ValidateWith validates an entry document against the rules selected by
+// opts.
+func (entry *Entry) ValidateWith(opts *ValidationOptions) error {
 	var errs = new(ValidationError)
 
 	errs.Merge(validateCommon(entry.Common))
@@ -174,32 +368,26 @@ func (entry *Entry) Validate() error {
 	}
 
 	for _, cat := range entry.Category {
-		errs.Merge(cat.validate())
+		errs.Merge(cat.validate(opts))
 	}
 
 	if entry.Content != nil {
-		errs.Merge(entry.Content.validate(true))
+		errs.Merge(entry.Content.validate(true, opts))
 	}
 
 	for _, contrib := range entry.Contributor {
 		errs.Merge(contrib.validate())
 	}
 
-	if entry.ID == "" {
-		errs.Add("Entry.ID can't be empty.")
-	}
+	validateAbsoluteID(entry.ID, "Entry.ID", opts, errs)
 
-	foundAlternate := false
 	for _, link := range entry.Link {
-		errs.Merge(link.validate())
+		errs.Merge(link.validate(opts))
+	}
+	validateLinkUniqueness(entry.Link, "Entry", opts, errs)
 
-		if link.Rel == "alternate" {
-			if foundAlternate {
-				errs.Add("Only one Feed.Link with rel=\"alternate\" can exist.")
-			} else {
-				foundAlternate = true
-			}
-		}
+	if entry.Media != nil {
+		errs.Merge(entry.Media.validate())
 	}
 
 	if entry.Published != "" {
@@ -213,7 +401,7 @@ func (entry *Entry) Validate() error {
 	}
 
 	if entry.Summary != nil {
-		errs.Merge(entry.Summary.validate(false))
+		errs.Merge(entry.Summary.validate(false, opts))
 	}
 
 	if entry.Title == "" {
@@ -227,16 +415,22 @@ func (entry *Entry) Validate() error {
 	}
 
 	if entry.Source != nil {
-		errs.Merge(entry.Source.Feed.Validate())
+		errs.Merge(entry.Source.Feed.ValidateWith(opts))
 	}
 
 	return errs.NilIfEmpty()
 }
 
-// Validate check the atom feed structure to make sure it's comformant with the
-// ATOM spec: https://tools.ietf.org/html/rfc4287. All validation errors are
-// returned together.
+// Validate check the atom feed structure to make sure it's comformant with
+// the ATOM spec: https://tools.ietf.org/html/rfc4287, using DefaultOptions.
+// All validation errors are returned together.
 func (feed *Feed) Validate() error {
+	return feed.ValidateWith(DefaultOptions)
+}
+
+// ValidateWith validates the feed against the rules selected by opts. All
+// validation errors are returned together.
+func (feed *Feed) ValidateWith(opts *ValidationOptions) error {
 	var errs = new(ValidationError)
 
 	errs.Merge(validateCommon(feed.Common))
@@ -256,7 +450,7 @@ func (feed *Feed) Validate() error {
 	}
 
 	for _, cat := range feed.Category {
-		errs.Merge(cat.validate())
+		errs.Merge(cat.validate(opts))
 	}
 
 	for _, contrib := range feed.Contributor {
@@ -264,7 +458,7 @@ func (feed *Feed) Validate() error {
 	}
 
 	if feed.Generator != nil {
-		errs.Merge(feed.Generator.validate())
+		errs.Merge(feed.Generator.validate(opts))
 	}
 
 	if feed.Icon != "" {
@@ -273,22 +467,12 @@ func (feed *Feed) Validate() error {
 		}
 	}
 
-	if feed.ID == "" {
-		errs.Add("Feed.ID can't be empty.")
-	}
+	validateAbsoluteID(feed.ID, "Feed.ID", opts, errs)
 
-	foundAlternate := false
 	for _, link := range feed.Link {
-		errs.Merge(link.validate())
-
-		if link.Rel == "alternate" {
-			if foundAlternate {
-				errs.Add("Only one Feed.Link with rel=\"alternate\" can exist.")
-			} else {
-				foundAlternate = true
-			}
-		}
+		errs.Merge(link.validate(opts))
 	}
+	validateLinkUniqueness(feed.Link, "Feed", opts, errs)
 
 	if feed.Logo != "" {
 		if _, err := url.Parse(feed.Logo); err != nil {
@@ -300,10 +484,6 @@ func (feed *Feed) Validate() error {
 		errs.Add("Feed.Title can't be empty.")
 	}
 
-	if feed.Updated == "" {
-		errs.Add("Feed.Updated can't be empty.")
-	}
-
 	if feed.Updated != "" {
 		errs.Merge(feed.Updated.validate())
 	} else {
@@ -311,7 +491,7 @@ func (feed *Feed) Validate() error {
 	}
 
 	for _, entry := range feed.Entry {
-		errs.Merge(entry.Validate())
+		errs.Merge(entry.ValidateWith(opts))
 	}
 
 	return errs.NilIfEmpty()
@@ -0,0 +1,65 @@
+package atom
+
+import "testing"
+
+func TestValidateWithRFC4287Strict(t *testing.T) {
+	feed := Feed{
+		ID:      "not-an-absolute-iri",
+		Title:   "Test feed",
+		Updated: "2006-11-04T09:11:03-08:00",
+		Author:  []Person{{Name: "John Doe"}},
+		Category: []Category{
+			{Scheme: "http://www.test.com/categories"},
+		},
+	}
+
+	if err := feed.Validate(); err != nil {
+		t.Fatal("Lenient (default) validation should not reject a relative Feed.ID or empty Category.Term: " + err.Error())
+	}
+
+	err := feed.ValidateWith(&ValidationOptions{Profile: RFC4287Strict})
+	if err == nil {
+		t.Fatal("RFC4287Strict validation should have rejected a relative Feed.ID and an empty Category.Term.")
+	}
+
+	valErr := err.(*ValidationError)
+	var sawID, sawTerm bool
+	for _, issue := range valErr.Issues() {
+		if issue.Path == "Feed.ID" {
+			sawID = true
+		}
+		if issue.Path == "Category.Term" {
+			sawTerm = true
+		}
+	}
+	if !sawID || !sawTerm {
+		t.Fatal("RFC4287Strict validation did not report the expected structured issues.")
+	}
+
+	feed.ID = "http://www.test.com/blog"
+	feed.Category[0].Term = "tech"
+	if err := feed.ValidateWith(&ValidationOptions{Profile: RFC4287Strict}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateWithRFC4287StrictLinkRelUniqueness(t *testing.T) {
+	feed := Feed{
+		ID:      "http://www.test.com/blog",
+		Title:   "Test feed",
+		Updated: "2006-11-04T09:11:03-08:00",
+		Author:  []Person{{Name: "John Doe"}},
+		Link: []Link{
+			{Rel: "alternate", Href: "http://www.test.com/blog", HrefLang: "en"},
+			{Rel: "alternate", Href: "http://www.test.com/blog.de", HrefLang: "de"},
+		},
+	}
+
+	if err := feed.Validate(); err == nil {
+		t.Fatal("Lenient validation should reject a second rel=\"alternate\" link even with a different hreflang.")
+	}
+
+	if err := feed.ValidateWith(&ValidationOptions{Profile: RFC4287Strict}); err != nil {
+		t.Fatal("RFC4287Strict validation should allow alternate links that differ by hreflang: " + err.Error())
+	}
+}